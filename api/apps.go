@@ -1,63 +1,20 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"github.com/ansible-semaphore/semaphore/api/helpers"
 	"github.com/ansible-semaphore/semaphore/db"
+	"github.com/ansible-semaphore/semaphore/pkg/appschema"
 	"github.com/ansible-semaphore/semaphore/util"
 	"github.com/gorilla/context"
+	"io"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
-func structToFlatMap(obj interface{}) map[string]interface{} {
-	result := make(map[string]interface{})
-	val := reflect.ValueOf(obj)
-	typ := reflect.TypeOf(obj)
-
-	if typ.Kind() == reflect.Ptr {
-		val = val.Elem()
-		typ = typ.Elem()
-	}
-
-	if typ.Kind() != reflect.Struct {
-		return result
-	}
-
-	// Iterate over the struct fields
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
-		fieldType := typ.Field(i)
-		jsonTag := fieldType.Tag.Get("json")
-
-		// Use the json tag if it is set, otherwise use the field name
-		fieldName := jsonTag
-		if fieldName == "" || fieldName == "-" {
-			fieldName = fieldType.Name
-		} else {
-			// Handle the case where the json tag might have options like `json:"name,omitempty"`
-			fieldName = strings.Split(fieldName, ",")[0]
-		}
-
-		// Check if the field is a struct itself
-		if field.Kind() == reflect.Struct {
-			// Convert nested struct to map
-			nestedMap := structToFlatMap(field.Interface())
-			// Add nested map to result with a prefixed key
-			for k, v := range nestedMap {
-				result[fieldName+"."+k] = v
-			}
-		} else {
-			result[fieldName] = field.Interface()
-		}
-	}
-
-	return result
-}
-
 func validateAppID(str string) error {
 	return nil
 }
@@ -79,7 +36,15 @@ func appMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// getApps lists every app, built-in template types included. If the request
+// carries a ?revision=N query param, it long-polls until util.Config's
+// revision differs from N (or the client disconnects) before responding, so
+// the UI can watch for edits made elsewhere without a fixed poll interval.
 func getApps(w http.ResponseWriter, r *http.Request) {
+	if after, err := strconv.ParseUint(r.URL.Query().Get("revision"), 10, 64); err == nil {
+		util.Config.WaitForChange(r.Context(), after)
+	}
+
 	defaultApps := map[string]util.App{
 		string(db.TemplateAnsible):    {},
 		string(db.TemplateTerraform):  {},
@@ -89,7 +54,7 @@ func getApps(w http.ResponseWriter, r *http.Request) {
 		string(db.TemplatePython):     {},
 	}
 
-	for k, a := range util.Config.Apps {
+	for k, a := range util.Config.Apps() {
 		defaultApps[k] = a
 	}
 
@@ -116,86 +81,125 @@ func getApps(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	w.Header().Set("X-Config-Revision", strconv.FormatUint(util.Config.Revision(), 10))
 	helpers.WriteJSON(w, http.StatusOK, apps)
 }
 
 func getApp(w http.ResponseWriter, r *http.Request) {
 	appID := context.Get(r, "app_id").(string)
 
-	app, ok := util.Config.Apps[appID]
+	app, ok := util.Config.Apps()[appID]
 	if !ok {
 		helpers.WriteErrorStatus(w, "app not found", http.StatusNotFound)
 		return
 	}
 
+	applySchemaDefaults(&app, appschema.Get(appID))
+
 	helpers.WriteJSON(w, http.StatusOK, app)
 }
 
-func deleteApp(w http.ResponseWriter, r *http.Request) {
-	appID := context.Get(r, "app_id").(string)
-
-	store := helpers.Store(r)
+// applySchemaDefaults fills every zero-valued field of app with the default
+// declared on the matching schema property, so a field the user never
+// overrode still reports a sensible value instead of Go's zero value.
+//
+// Bool fields are skipped deliberately: IsZero() can't tell "never set" from
+// "explicitly set to false", so applying a `default=true` schema default to
+// a bool would flip a user's explicit false back to true (e.g. an app they
+// disabled via setAppActive would report active again from getApp).
+func applySchemaDefaults(app *util.App, schema *appschema.Schema) {
+	applyDefaults(reflect.ValueOf(app).Elem(), schema)
+}
 
-	err := store.DeleteOptions("apps." + appID)
-	if err != nil && !errors.Is(err, db.ErrNotFound) {
-		helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+func applyDefaults(val reflect.Value, schema *appschema.Schema) {
+	typ := val.Type()
 
-	delete(util.Config.Apps, appID)
+	for i := 0; i < typ.NumField(); i++ {
+		field := val.Field(i)
+		if typ.Field(i).Anonymous {
+			// Embedded option structs (util.BashAppOptions, ...) have their
+			// fields at the app's own level in the schema, so recurse.
+			applyDefaults(field, schema)
+			continue
+		}
+		if field.Kind() == reflect.Bool {
+			continue
+		}
 
-	w.WriteHeader(http.StatusNoContent)
-}
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
 
-func setAppOption(store db.Store, appID string, field string, val interface{}) error {
-	key := "apps." + appID + "." + field
+		prop, ok := schema.Properties[name]
+		if !ok || prop.Default == nil {
+			continue
+		}
 
-	v := fmt.Sprintf("%v", val)
+		if !field.IsZero() {
+			continue
+		}
 
-	if err := store.SetOption(key, v); err != nil {
-		return err
+		if def, ok := prop.Default.(string); ok && field.Kind() == reflect.String {
+			field.SetString(def)
+		}
 	}
+}
 
-	opts := make(map[string]string)
-	opts[key] = v
+func getAppSchema(w http.ResponseWriter, r *http.Request) {
+	appID := context.Get(r, "app_id").(string)
 
-	options := db.ConvertFlatToNested(opts)
+	helpers.WriteJSON(w, http.StatusOK, appschema.Get(appID))
+}
 
-	_ = db.AssignMapToStruct(options, util.Config)
+func deleteApp(w http.ResponseWriter, r *http.Request) {
+	appID := context.Get(r, "app_id").(string)
 
-	return nil
+	err := util.Config.WithApps(func(apps map[string]util.App) error {
+		delete(apps, appID)
+		return nil
+	})
+	if err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 func setApp(w http.ResponseWriter, r *http.Request) {
 	appID := context.Get(r, "app_id").(string)
 
-	store := helpers.Store(r)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	var app util.App
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if !helpers.Bind(w, r, &app) {
+	if err := appschema.Validate(appschema.Get(appID), raw); err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	options := structToFlatMap(app)
+	// helpers.Bind needs to read the body again, so hand it an unread copy.
+	r.Body = io.NopCloser(bytes.NewReader(body))
 
-	for k, v := range options {
-		t := reflect.TypeOf(v)
-		switch t.Kind() {
-		case reflect.Slice, reflect.Array:
-			newV, err := json.Marshal(v)
-			if err != nil {
-				helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			v = string(newV)
-		default:
-		}
+	var app util.App
 
-		if err := setAppOption(store, appID, k, v); err != nil {
-			helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	if !helpers.Bind(w, r, &app) {
+		return
+	}
+
+	err = util.Config.WithApps(func(apps map[string]util.App) error {
+		apps[appID] = app
+		return nil
+	})
+	if err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
@@ -204,8 +208,6 @@ func setApp(w http.ResponseWriter, r *http.Request) {
 func setAppActive(w http.ResponseWriter, r *http.Request) {
 	appID := context.Get(r, "app_id").(string)
 
-	store := helpers.Store(r)
-
 	var body struct {
 		Active bool `json:"active"`
 	}
@@ -214,7 +216,13 @@ func setAppActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := setAppOption(store, appID, "active", body.Active); err != nil {
+	err := util.Config.WithApps(func(apps map[string]util.App) error {
+		app := apps[appID]
+		app.Active = body.Active
+		apps[appID] = app
+		return nil
+	})
+	if err != nil {
 		helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
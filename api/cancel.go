@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ansible-semaphore/semaphore/api/helpers"
+	"github.com/ansible-semaphore/semaphore/pkg/tasks"
+	"github.com/gorilla/context"
+)
+
+// cancelApp handles POST /api/apps/{app_id}/cancel: every task currently
+// running under app_id is sent SIGTERM (escalating to SIGKILL if it doesn't
+// stop -- see pkg/tasks.Run.Cancel).
+func cancelApp(w http.ResponseWriter, r *http.Request) {
+	appID := context.Get(r, "app_id").(string)
+
+	tasks.CancelApp(appID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// cancelProjectTask handles POST /api/project/{id}/tasks/{tid}/cancel.
+func cancelProjectTask(w http.ResponseWriter, r *http.Request) {
+	tidParam, err := helpers.GetStrParam("tid", w, r)
+	if err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	taskID, err := strconv.Atoi(tidParam)
+	if err != nil {
+		helpers.WriteErrorStatus(w, "invalid task id", http.StatusBadRequest)
+		return
+	}
+
+	if !tasks.CancelTask(taskID) {
+		helpers.WriteErrorStatus(w, "task not found or already finished", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
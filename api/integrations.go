@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ansible-semaphore/semaphore/api/helpers"
+	"github.com/ansible-semaphore/semaphore/db"
+	"github.com/ansible-semaphore/semaphore/util"
+	"github.com/gorilla/context"
+)
+
+func validateTrackerID(str string) error {
+	return nil
+}
+
+func integrationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trackerID, err := helpers.GetStrParam("tracker_id", w, r)
+		if err != nil {
+			helpers.WriteErrorStatus(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := validateTrackerID(trackerID); err != nil {
+			helpers.WriteErrorStatus(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		context.Set(r, "tracker_id", trackerID)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func getIntegrations(w http.ResponseWriter, r *http.Request) {
+	integrations := util.Config.Integrations()
+
+	trackers := make([]db.IntegrationTracker, 0, len(integrations))
+	for _, tracker := range integrations {
+		trackers = append(trackers, tracker.Redacted())
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, trackers)
+}
+
+func getIntegration(w http.ResponseWriter, r *http.Request) {
+	trackerID := context.Get(r, "tracker_id").(string)
+
+	tracker, ok := util.Config.Integrations()[trackerID]
+	if !ok {
+		helpers.WriteErrorStatus(w, "tracker not found", http.StatusNotFound)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, tracker.Redacted())
+}
+
+func deleteIntegration(w http.ResponseWriter, r *http.Request) {
+	trackerID := context.Get(r, "tracker_id").(string)
+
+	err := util.Config.WithIntegrations(func(integrations map[string]db.IntegrationTracker) error {
+		delete(integrations, trackerID)
+		return nil
+	})
+	if err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setIntegration(w http.ResponseWriter, r *http.Request) {
+	trackerID := context.Get(r, "tracker_id").(string)
+
+	var tracker db.IntegrationTracker
+
+	if !helpers.Bind(w, r, &tracker) {
+		return
+	}
+
+	tracker.AppID = trackerID
+
+	err := util.Config.WithIntegrations(func(integrations map[string]db.IntegrationTracker) error {
+		integrations[trackerID] = tracker
+		return nil
+	})
+	if err != nil {
+		helpers.WriteErrorStatus(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ansible-semaphore/semaphore/pkg/options"
+)
+
+// ConvertFlatToNested turns a flat "a.b.c" -> tagged-value map (as read from
+// or written to the options store) into maps nested by path segment, ready
+// for AssignMapToStruct. util.MigrateFlatAppOptions is the production caller,
+// porting options written before util.ConfigStore existed.
+func ConvertFlatToNested(flat map[string]string) map[string]interface{} {
+	nested := map[string]interface{}{}
+
+	for key, value := range flat {
+		segments := strings.Split(key, ".")
+		cur := nested
+
+		for i, seg := range segments {
+			if i == len(segments)-1 {
+				cur[seg] = value
+				continue
+			}
+
+			next, ok := cur[seg].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				cur[seg] = next
+			}
+			cur = next
+		}
+	}
+
+	return nested
+}
+
+// AssignMapToStruct decodes nested (as produced by ConvertFlatToNested) into
+// target, which must be a pointer to a struct. Keys with no matching field
+// are ignored, since nested typically carries only the options that changed
+// rather than a full snapshot.
+func AssignMapToStruct(nested map[string]interface{}, target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("AssignMapToStruct: target must be a non-nil pointer")
+	}
+
+	return assignStruct(nested, val.Elem())
+}
+
+func assignStruct(nested map[string]interface{}, val reflect.Value) error {
+	typ := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := typ.Field(i)
+
+		if field.Anonymous {
+			// An embedded struct's fields live at the same level in nested
+			// as the parent's own fields (see Flatten), not under a
+			// sub-map keyed by the embedded type's name -- so recurse with
+			// the same nested map rather than looking up a key first.
+			if err := assignStruct(nested, val.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		child, ok := nested[options.FieldName(field)]
+		if !ok {
+			continue
+		}
+
+		if err := assignValue(child, val.Field(i)); err != nil {
+			return fmt.Errorf("AssignMapToStruct: field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func assignMap(nested map[string]interface{}, val reflect.Value) error {
+	if val.IsNil() {
+		val.Set(reflect.MakeMap(val.Type()))
+	}
+
+	for key, child := range nested {
+		elem := reflect.New(val.Type().Elem()).Elem()
+		if existing := val.MapIndex(reflect.ValueOf(key)); existing.IsValid() {
+			elem.Set(existing)
+		}
+
+		if err := assignValue(child, elem); err != nil {
+			return fmt.Errorf("AssignMapToStruct: key %s: %w", key, err)
+		}
+
+		val.SetMapIndex(reflect.ValueOf(key), elem)
+	}
+
+	return nil
+}
+
+func assignValue(child interface{}, field reflect.Value) error {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+
+	switch v := child.(type) {
+	case string:
+		return options.Decode(v, field)
+	case map[string]interface{}:
+		switch field.Kind() {
+		case reflect.Struct:
+			return assignStruct(v, field)
+		case reflect.Map:
+			return assignMap(v, field)
+		default:
+			return fmt.Errorf("cannot assign nested map into %s", field.Kind())
+		}
+	default:
+		return fmt.Errorf("unsupported nested value type %T", child)
+	}
+}
@@ -0,0 +1,46 @@
+package db
+
+// IntegrationTrackerKind identifies which issue-tracker backend a
+// IntegrationTracker record talks to.
+type IntegrationTrackerKind string
+
+const (
+	IntegrationTrackerJira   IntegrationTrackerKind = "jira"
+	IntegrationTrackerGithub IntegrationTrackerKind = "github"
+	IntegrationTrackerGitlab IntegrationTrackerKind = "gitlab"
+)
+
+// IntegrationTracker stores the connection details Semaphore needs to open a
+// ticket in an external issue tracker when a template task fails. One record
+// is kept per app (the same app_id used by util.App), in
+// util.Config.Integrations keyed by that app_id.
+type IntegrationTracker struct {
+	AppID    string                 `json:"app_id" db:"app_id"`
+	Kind     IntegrationTrackerKind `json:"kind" db:"kind"`
+	Endpoint string                 `json:"endpoint"`
+	// ProjectKey is the Jira/GitLab project key or the "owner/repo" slug for GitHub.
+	ProjectKey string `json:"project_key"`
+	// CredentialRef is the raw bearer token/PAT sent as-is in the
+	// Authorization/PRIVATE-TOKEN header of every tracker API call (see
+	// pkg/trackers) -- despite the name, it is not an indirection into a
+	// separate secret store, and pkg/trackers has no such store to resolve
+	// one against. Since this is a real credential, never log it, and use
+	// Redacted rather than returning a tracker struct to an API client
+	// as-is.
+	CredentialRef string `json:"credential_ref"`
+	IssueType     string `json:"issue_type"`
+	// Fields maps user-defined issue fields to a template string evaluated
+	// against the TaskFailure, e.g. {"customfield_10010": "{{.TemplateName}}"}.
+	// The Jira tracker sends every entry as an issue field; the GitHub and
+	// GitLab trackers read only the "labels" entry and ignore the rest.
+	Fields map[string]string `json:"fields"`
+	Active bool              `json:"active"`
+}
+
+// Redacted returns a copy of t with CredentialRef blanked out. API handlers
+// that read back a tracker (GET /api/integrations[/{tracker_id}]) must
+// return this, never t itself, so a stored PAT is never echoed to a client.
+func (t IntegrationTracker) Redacted() IntegrationTracker {
+	t.CredentialRef = ""
+	return t
+}
@@ -0,0 +1,147 @@
+package appschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Generate reflects over one or more structs (typically util.App plus a
+// template-type-specific options struct) and merges their fields into a
+// single flat schema. Embedded structs' fields are promoted to the top level,
+// as encoding/json and options.Flatten do; other nested structs become one
+// "object" property each.
+func Generate(structs ...interface{}) *Schema {
+	schema := &Schema{
+		Schema:               draft202012,
+		Type:                 "object",
+		Properties:           map[string]*Property{},
+		AdditionalProperties: false,
+	}
+
+	for _, s := range structs {
+		addFields(schema, s)
+	}
+
+	return schema
+}
+
+func addFields(schema *Schema, s interface{}) {
+	val := reflect.ValueOf(s)
+	typ := reflect.TypeOf(s)
+
+	if typ.Kind() == reflect.Ptr {
+		val = val.Elem()
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		if field.Anonymous {
+			// An embedded struct's fields are promoted to the parent's own
+			// level by encoding/json (and by Flatten/AssignMapToStruct,
+			// which mirror that) -- recurse rather than adding a single
+			// opaque "object" property for the embedded type itself.
+			addFields(schema, reflect.New(field.Type).Elem().Interface())
+			continue
+		}
+
+		name := field.Tag.Get("json")
+		if name == "" || name == "-" {
+			name = field.Name
+		} else {
+			name = strings.Split(name, ",")[0]
+		}
+
+		prop, required := propertyFromTag(field.Tag.Get("jsonschema"), field.Type)
+		schema.Properties[name] = prop
+
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// propertyFromTag parses a comma-separated `key=value` list, e.g.
+// `title=Shell,enum=bash|sh|zsh,default=bash,required`.
+func propertyFromTag(tag string, fieldType reflect.Type) (*Property, bool) {
+	prop := &Property{Type: jsonType(fieldType)}
+	required := false
+
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+		key := kv[0]
+		value := ""
+		if len(kv) == 2 {
+			value = kv[1]
+		}
+
+		switch key {
+		case "title":
+			prop.Title = value
+		case "description":
+			prop.Description = value
+		case "format":
+			prop.Format = value
+		case "enum":
+			prop.Enum = strings.Split(value, "|")
+		case "default":
+			if value != "" {
+				prop.Default = coerceDefault(value, prop.Type)
+			}
+		case "maxLength":
+			if n, err := strconv.Atoi(value); err == nil {
+				prop.MaxLength = n
+			}
+		case "required":
+			required = true
+		}
+	}
+
+	return prop, required
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct:
+		// Nested structs (e.g. util.AppTimeouts) aren't decomposed into their
+		// own properties -- Generate only flattens the top-level struct(s) it
+		// is given -- so the UI just gets "this is an object" and renders it
+		// as an opaque JSON field rather than individual inputs.
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+func coerceDefault(value string, jsonType string) interface{} {
+	switch jsonType {
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return value
+		}
+		return b
+	default:
+		return value
+	}
+}
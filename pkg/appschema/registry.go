@@ -0,0 +1,31 @@
+package appschema
+
+import (
+	"github.com/ansible-semaphore/semaphore/db"
+	"github.com/ansible-semaphore/semaphore/util"
+)
+
+// registry holds one generated Schema per template type, keyed the same way
+// util.Config.Apps is (db.TemplateType, e.g. "bash").
+//
+// util.App embeds both util.AnsibleAppOptions and util.BashAppOptions, so
+// every template type's schema includes shell_type and playbook_flags --
+// there's no per-type App variant to generate a narrower schema from.
+var registry = map[string]*Schema{
+	string(db.TemplateAnsible):    Generate(util.App{}),
+	string(db.TemplateTerraform):  Generate(util.App{}),
+	string(db.TemplateTofu):       Generate(util.App{}),
+	string(db.TemplateBash):       Generate(util.App{}),
+	string(db.TemplatePowerShell): Generate(util.App{}),
+	string(db.TemplatePython):     Generate(util.App{}),
+}
+
+// Get returns the schema registered for a template type. Apps that aren't
+// one of the built-in template types (custom apps added at runtime) fall
+// back to the bare util.App schema.
+func Get(appID string) *Schema {
+	if schema, ok := registry[appID]; ok {
+		return schema
+	}
+	return Generate(util.App{})
+}
@@ -0,0 +1,31 @@
+// Package appschema generates and validates JSON Schema (Draft 2020-12)
+// descriptions of the per-template-type app config structs in util, so the
+// same definition drives both API-side validation and the dynamic UI form
+// served by GET /api/apps/{app_id}/schema.
+package appschema
+
+// Schema is the subset of Draft 2020-12 Semaphore needs to describe a flat
+// options struct: a nested object is one property of type "object" with no
+// properties of its own; no $ref, no oneOf/anyOf.
+type Schema struct {
+	Schema               string               `json:"$schema"`
+	Type                 string               `json:"type"`
+	Properties           map[string]*Property `json:"properties"`
+	Required             []string             `json:"required,omitempty"`
+	AdditionalProperties bool                 `json:"additionalProperties"`
+}
+
+// Property describes one field: enough for a form renderer to pick a widget
+// (enum -> select, format -> specialized input) and for the API to validate
+// a submitted value.
+type Property struct {
+	Type        string      `json:"type"`
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Enum        []string    `json:"enum,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	MaxLength   int         `json:"maxLength,omitempty"`
+}
+
+const draft202012 = "https://json-schema.org/draft/2020-12/schema"
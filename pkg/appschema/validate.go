@@ -0,0 +1,132 @@
+package appschema
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+)
+
+// Validate checks a flattened "field name -> raw value" map (as produced by
+// decoding a setApp request body into map[string]interface{}) against
+// schema, rejecting unknown fields, type mismatches, enum/required
+// violations, max length and url/path format constraints.
+func Validate(schema *Schema, data map[string]interface{}) error {
+	for _, name := range schema.Required {
+		if _, ok := data[name]; !ok {
+			return fmt.Errorf("missing required field %q", name)
+		}
+	}
+
+	for name, value := range data {
+		prop, ok := schema.Properties[name]
+		if !ok {
+			if !schema.AdditionalProperties {
+				return fmt.Errorf("unknown field %q", name)
+			}
+			continue
+		}
+
+		if err := validateValue(name, prop, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateValue(name string, prop *Property, value interface{}) error {
+	if err := validateType(name, prop, value); err != nil {
+		return err
+	}
+
+	if len(prop.Enum) > 0 {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("field %q: expected one of %v", name, prop.Enum)
+		}
+
+		found := false
+		for _, allowed := range prop.Enum {
+			if allowed == str {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("field %q: %q is not one of %v", name, str, prop.Enum)
+		}
+	}
+
+	if prop.MaxLength > 0 {
+		if str, ok := value.(string); ok && len(str) > prop.MaxLength {
+			return fmt.Errorf("field %q: exceeds max length %d", name, prop.MaxLength)
+		}
+	}
+
+	if prop.Format != "" {
+		if str, ok := value.(string); ok {
+			if err := validateFormat(name, prop.Format, str); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateType checks value's decoded-JSON type (bool, float64, string,
+// []interface{}, map[string]interface{} -- whatever encoding/json produced
+// unmarshaling into map[string]interface{}) against prop.Type, so e.g. a
+// number submitted for a string field is rejected here instead of either
+// passing silently or failing later, confusingly, in helpers.Bind.
+func validateType(name string, prop *Property, value interface{}) error {
+	switch prop.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("field %q: expected a string, got %T", name, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("field %q: expected a boolean, got %T", name, value)
+		}
+	case "integer":
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) {
+			return fmt.Errorf("field %q: expected an integer, got %v", name, value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("field %q: expected a number, got %T", name, value)
+		}
+	case "array":
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Errorf("field %q: expected an array, got %T", name, value)
+		}
+	case "object":
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Errorf("field %q: expected an object, got %T", name, value)
+		}
+	}
+
+	return nil
+}
+
+// validateFormat checks the "format" constraints the request asked for.
+// Unrecognized formats (e.g. "color", used by util.App today) are left
+// unvalidated rather than rejected, since Property.Format is free-form.
+func validateFormat(name, format, value string) error {
+	switch format {
+	case "url":
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("field %q: %q is not a valid url", name, value)
+		}
+	case "path":
+		if value == "" || !strings.HasPrefix(value, "/") {
+			return fmt.Errorf("field %q: %q is not an absolute path", name, value)
+		}
+	}
+
+	return nil
+}
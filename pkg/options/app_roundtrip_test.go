@@ -0,0 +1,65 @@
+package options_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ansible-semaphore/semaphore/db"
+	"github.com/ansible-semaphore/semaphore/pkg/options"
+	"github.com/ansible-semaphore/semaphore/util"
+)
+
+// fakeStore stands in for db.Store: a flat key->tagged-value map, which is
+// all ConvertFlatToNested/AssignMapToStruct ever see of the real store.
+type fakeStore map[string]string
+
+func (s fakeStore) set(key string, val interface{}) error {
+	encoded, err := options.Encode(val)
+	if err != nil {
+		return err
+	}
+	s[key] = encoded
+	return nil
+}
+
+// TestRoundTripApp flattens a util.App, writes every field through the
+// codec into a fake store, then reassembles a util.App from the resulting
+// flat map and checks it matches the original -- the same path setApp/getApp
+// take in production, minus the HTTP plumbing. This calls the real
+// db.ConvertFlatToNested/db.AssignMapToStruct (this package is
+// options_test, not options, specifically so it can import db alongside
+// options/util without an import cycle) so a bug in either -- like a
+// panic decoding into a uint field -- is caught here rather than by a
+// parallel reimplementation that could drift from the real thing.
+func TestRoundTripApp(t *testing.T) {
+	cases := []util.App{
+		{},
+		{Title: "Ansible", Icon: "ansible", Color: "#fff", DarkColor: "#000", Active: true},
+		{Title: "", Active: false},
+		{Title: "contains:colon and, comma"},
+		{Title: "Bash", Timeouts: util.AppTimeouts{Connect: 5, Step: 30, Overall: 600}},
+		{Title: "Ansible", AnsibleAppOptions: util.AnsibleAppOptions{PlaybookFlags: "-vvv"}},
+		{Title: "Bash", BashAppOptions: util.BashAppOptions{ShellType: "zsh"}},
+	}
+
+	for _, original := range cases {
+		store := fakeStore{}
+
+		for key, val := range options.Flatten(original) {
+			if err := store.set(key, val); err != nil {
+				t.Fatalf("store.set(%s): %v", key, err)
+			}
+		}
+
+		nested := db.ConvertFlatToNested(store)
+
+		var roundTripped util.App
+		if err := db.AssignMapToStruct(nested, &roundTripped); err != nil {
+			t.Fatalf("AssignMapToStruct: %v", err)
+		}
+
+		if !reflect.DeepEqual(original, roundTripped) {
+			t.Fatalf("round trip mismatch: got %+v, want %+v", roundTripped, original)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package options is the typed codec behind Semaphore's "apps.<id>.<field>"
+// style config options. Every value is stored as "<tag>:<payload>" so the
+// reader round-trips it to the exact Go value that was written instead of
+// the ad-hoc fmt.Sprintf("%v", ...) coercion the API used to rely on.
+package options
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tagBool   = "bool"
+	tagInt    = "int"
+	tagFloat  = "float"
+	tagString = "string"
+	tagTime   = "time"
+	tagJSON   = "json"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Encode renders val as a tagged string suitable for db.Store.SetOption.
+func Encode(val interface{}) (string, error) {
+	if val == nil {
+		return tagString + ":", nil
+	}
+
+	if t, ok := val.(time.Time); ok {
+		return tagTime + ":" + t.Format(time.RFC3339Nano), nil
+	}
+
+	rv := reflect.ValueOf(val)
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return tagBool + ":" + strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return tagInt + ":" + strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return tagInt + ":" + strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return tagFloat + ":" + strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	case reflect.String:
+		return tagString + ":" + rv.String(), nil
+	default:
+		encoded, err := jsonMarshal(val)
+		if err != nil {
+			return "", fmt.Errorf("options: encode %T: %w", val, err)
+		}
+		return tagJSON + ":" + encoded, nil
+	}
+}
+
+// Decode parses a tagged string produced by Encode (or a legacy untagged
+// value written before this codec existed) into target, which must be
+// addressable and settable.
+func Decode(tagged string, target reflect.Value) error {
+	if !target.CanSet() {
+		return fmt.Errorf("options: decode target is not settable")
+	}
+
+	tag, payload, found := strings.Cut(tagged, ":")
+	if found {
+		switch tag {
+		case tagBool:
+			b, err := strconv.ParseBool(payload)
+			if err != nil {
+				return err
+			}
+			if target.Kind() != reflect.Bool {
+				return fmt.Errorf("options: cannot decode bool into %s", target.Kind())
+			}
+			target.SetBool(b)
+			return nil
+		case tagInt:
+			switch {
+			case target.Kind() >= reflect.Int && target.Kind() <= reflect.Int64:
+				n, err := strconv.ParseInt(payload, 10, 64)
+				if err != nil {
+					return err
+				}
+				target.SetInt(n)
+				return nil
+			case target.Kind() >= reflect.Uint && target.Kind() <= reflect.Uint64:
+				n, err := strconv.ParseUint(payload, 10, 64)
+				if err != nil {
+					return err
+				}
+				target.SetUint(n)
+				return nil
+			default:
+				return fmt.Errorf("options: cannot decode int into %s", target.Kind())
+			}
+		case tagFloat:
+			f, err := strconv.ParseFloat(payload, 64)
+			if err != nil {
+				return err
+			}
+			target.SetFloat(f)
+			return nil
+		case tagString:
+			if target.Kind() != reflect.String {
+				return fmt.Errorf("options: cannot decode string into %s", target.Kind())
+			}
+			target.SetString(payload)
+			return nil
+		case tagTime:
+			t, err := time.Parse(time.RFC3339Nano, payload)
+			if err != nil {
+				return err
+			}
+			target.Set(reflect.ValueOf(t))
+			return nil
+		case tagJSON:
+			return jsonUnmarshal(payload, target)
+		}
+	}
+
+	// Legacy/back-compat path: values written before this codec existed (or
+	// by a caller that bypassed Encode) have no type tag at all.
+	return decodeLegacy(tagged, target)
+}
+
+func decodeLegacy(raw string, target reflect.Value) error {
+	switch target.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		target.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		target.SetFloat(f)
+	default:
+		// Legacy slices were stored as bare JSON with no tag prefix.
+		return jsonUnmarshal(raw, target)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package options
+
+import (
+	"reflect"
+	"testing"
+)
+
+func FuzzEncodeDecodeString(f *testing.F) {
+	f.Add("")
+	f.Add("plain value")
+	f.Add("contains:colon")
+	f.Add("unicode é中")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		tagged, err := Encode(s)
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+
+		var out string
+		target := reflect.ValueOf(&out).Elem()
+		if err := Decode(tagged, target); err != nil {
+			t.Fatalf("Decode(%q): %v", tagged, err)
+		}
+
+		if out != s {
+			t.Fatalf("round trip mismatch: got %q, want %q", out, s)
+		}
+	})
+}
+
+func FuzzEncodeDecodeBool(f *testing.F) {
+	f.Add(true)
+	f.Add(false)
+
+	f.Fuzz(func(t *testing.T, b bool) {
+		tagged, err := Encode(b)
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+
+		var out bool
+		target := reflect.ValueOf(&out).Elem()
+		if err := Decode(tagged, target); err != nil {
+			t.Fatalf("Decode(%q): %v", tagged, err)
+		}
+
+		if out != b {
+			t.Fatalf("round trip mismatch: got %v, want %v", out, b)
+		}
+	})
+}
+
+func FuzzEncodeDecodeInt(f *testing.F) {
+	f.Add(0)
+	f.Add(-1)
+	f.Add(1 << 30)
+
+	f.Fuzz(func(t *testing.T, n int) {
+		tagged, err := Encode(n)
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+
+		var out int
+		target := reflect.ValueOf(&out).Elem()
+		if err := Decode(tagged, target); err != nil {
+			t.Fatalf("Decode(%q): %v", tagged, err)
+		}
+
+		if out != n {
+			t.Fatalf("round trip mismatch: got %d, want %d", out, n)
+		}
+	})
+}
+
+func FuzzEncodeDecodeUint(f *testing.F) {
+	f.Add(uint(0))
+	f.Add(uint(1))
+	f.Add(uint(1 << 30))
+
+	f.Fuzz(func(t *testing.T, n uint) {
+		tagged, err := Encode(n)
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+
+		var out uint
+		target := reflect.ValueOf(&out).Elem()
+		if err := Decode(tagged, target); err != nil {
+			t.Fatalf("Decode(%q): %v", tagged, err)
+		}
+
+		if out != n {
+			t.Fatalf("round trip mismatch: got %d, want %d", out, n)
+		}
+	})
+}
+
+func TestDecodeLegacyUntaggedValues(t *testing.T) {
+	cases := []struct {
+		raw    string
+		target interface{}
+	}{
+		{"true", new(bool)},
+		{"some title", new(string)},
+		{"42", new(int)},
+	}
+
+	for _, c := range cases {
+		target := reflect.ValueOf(c.target).Elem()
+		if err := Decode(c.raw, target); err != nil {
+			t.Errorf("Decode(%q): %v", c.raw, err)
+		}
+	}
+}
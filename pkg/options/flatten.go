@@ -0,0 +1,74 @@
+package options
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Flatten walks obj (a struct, typically util.App or a db config record)
+// and produces a "dot.path" -> raw Go value map. Nested structs and
+// non-empty maps are recursed into using the same dot-notation, so a field
+// like IntegrationTracker.Fields["labels"] flattens to
+// "fields.labels" rather than being opaquely JSON-dumped as a whole map.
+// The values returned are the original Go values (not yet tagged) -- callers
+// pass them through Encode before persisting.
+func Flatten(obj interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	flattenInto(result, "", reflect.ValueOf(obj))
+	return result
+}
+
+func flattenInto(result map[string]interface{}, prefix string, val reflect.Value) {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+
+	switch {
+	case val.Kind() == reflect.Struct && val.Type() != timeType:
+		typ := val.Type()
+		for i := 0; i < val.NumField(); i++ {
+			field := typ.Field(i)
+			if field.Anonymous {
+				// encoding/json promotes an embedded struct's fields to the
+				// parent's own level instead of nesting them under the
+				// embedded type's name -- match that here, or Flatten would
+				// produce e.g. "ansibleappoptions.playbook_flags" for a key
+				// that's actually just "playbook_flags".
+				flattenInto(result, prefix, val.Field(i))
+				continue
+			}
+			flattenInto(result, joinKey(prefix, FieldName(field)), val.Field(i))
+		}
+	case val.Kind() == reflect.Map && val.Len() > 0:
+		iter := val.MapRange()
+		for iter.Next() {
+			key := fmt.Sprintf("%v", iter.Key().Interface())
+			flattenInto(result, joinKey(prefix, key), iter.Value())
+		}
+	default:
+		if prefix != "" {
+			result[prefix] = val.Interface()
+		}
+	}
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// FieldName returns the option key a struct field is stored under: its json
+// tag name, falling back to the Go field name.
+func FieldName(field reflect.StructField) string {
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "" || jsonTag == "-" {
+		return field.Name
+	}
+	return strings.Split(jsonTag, ",")[0]
+}
@@ -0,0 +1,23 @@
+package options
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+func jsonMarshal(val interface{}) (string, error) {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func jsonUnmarshal(payload string, target reflect.Value) error {
+	ptr := reflect.New(target.Type())
+	if err := json.Unmarshal([]byte(payload), ptr.Interface()); err != nil {
+		return err
+	}
+	target.Set(ptr.Elem())
+	return nil
+}
@@ -0,0 +1,50 @@
+package tasks
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer lets the overall-timeout and any other deadline on a Run be
+// (re)armed from whichever goroutine learns about it first -- the API
+// handler setting a cancel, the runner loop checking the app's configured
+// timeout -- without them stepping on each other.
+type deadlineTimer struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	fn    func()
+}
+
+func newDeadlineTimer(fn func()) *deadlineTimer {
+	return &deadlineTimer{fn: fn}
+}
+
+// SetDeadline (re)arms the timer to call fn after duration, replacing
+// whatever deadline was previously set. duration <= 0 disables it. Safe for
+// concurrent use.
+func (d *deadlineTimer) SetDeadline(duration time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+
+	if duration <= 0 {
+		d.timer = nil
+		return
+	}
+
+	d.timer = time.AfterFunc(duration, d.fn)
+}
+
+// Stop disarms the timer; fn will not fire unless SetDeadline is called again.
+func (d *deadlineTimer) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
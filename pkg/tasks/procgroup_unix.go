@@ -0,0 +1,22 @@
+//go:build !windows
+
+package tasks
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func setpgid() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killGroup signals cmd's whole process group (negative PID) rather than
+// just the direct child -- setpgid above makes the child its own group
+// leader, so this also reaches ansible-playbook/terraform's own subprocesses.
+func killGroup(cmd *exec.Cmd, sig syscall.Signal) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = syscall.Kill(-cmd.Process.Pid, sig)
+}
@@ -0,0 +1,21 @@
+//go:build windows
+
+package tasks
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func setpgid() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{}
+}
+
+// killGroup has no POSIX process-group signal to fan out to children on
+// Windows, so it just kills the top-level process.
+func killGroup(cmd *exec.Cmd, _ syscall.Signal) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = cmd.Process.Kill()
+}
@@ -0,0 +1,189 @@
+// Package tasks tracks in-flight task executions so the API layer can
+// cancel them -- either one at a time or every task running under a given
+// app -- and so each step is bounded by the owning app's configured
+// timeouts rather than running forever.
+//
+// NewRun, Run.Command and Run.Finish are the integration points the task
+// runner calls: NewRun when a task starts, Command for every exec.Cmd it
+// launches on the run's behalf, and Finish (with failure details, if any)
+// once the task reaches a terminal state -- which is also what drives
+// trackers.NotifyTaskFailure.
+package tasks
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ansible-semaphore/semaphore/pkg/trackers"
+	"github.com/ansible-semaphore/semaphore/util"
+)
+
+// gracePeriod is how long the cmd.Cancel hook installed by Command waits
+// after SIGTERM before escalating to SIGKILL.
+const gracePeriod = 10 * time.Second
+
+// Run is the cancellation handle for one task execution: its context is
+// wired into every exec.CommandContext call the runner makes on its behalf,
+// so canceling it (client disconnect, explicit cancel, or a configured
+// deadline) stops whatever step is currently running.
+type Run struct {
+	AppID  string
+	TaskID int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	overall *deadlineTimer
+
+	mu    sync.Mutex
+	flush func()
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[int]*Run{}
+)
+
+// NewRun registers a Run for taskID under appID. parent is typically the
+// context of the HTTP request that started the task (so a client disconnect
+// cancels it); app.Timeouts.Overall, if set, arms an additional deadline on
+// top of that.
+func NewRun(parent context.Context, appID string, taskID int, app util.App) *Run {
+	ctx, cancel := context.WithCancel(parent)
+
+	run := &Run{
+		AppID:  appID,
+		TaskID: taskID,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	run.overall = newDeadlineTimer(run.cancel)
+
+	if app.Timeouts.Overall > 0 {
+		run.overall.SetDeadline(app.Timeouts.Overall)
+	}
+
+	registryMu.Lock()
+	registry[taskID] = run
+	registryMu.Unlock()
+
+	return run
+}
+
+// Context is the run's base context: honor it (or a child of it, see
+// WithStepTimeout) in every exec.CommandContext call made for this task.
+func (r *Run) Context() context.Context {
+	return r.ctx
+}
+
+// WithStepTimeout returns a context bounded by both r and, if step > 0, an
+// additional per-step deadline -- the app's Timeouts.Step.
+func (r *Run) WithStepTimeout(step time.Duration) (context.Context, context.CancelFunc) {
+	if step <= 0 {
+		return r.ctx, func() {}
+	}
+	return context.WithTimeout(r.ctx, step)
+}
+
+// SetFlushHook registers a callback the runner uses to flush partial task
+// output to the log before Cancel signals the process.
+func (r *Run) SetFlushHook(flush func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.flush = flush
+}
+
+// Command builds an exec.Cmd bound to ctx (normally r.Context() or the
+// result of WithStepTimeout) and wires its process-group SIGTERM ->
+// SIGKILL escalation to ctx's own cancellation, via cmd.Cancel/cmd.WaitDelay
+// rather than Cancel signalling the process directly -- see Cancel.
+func (r *Run) Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.SysProcAttr = setpgid()
+
+	// exec.CommandContext's default behavior on ctx cancellation is an
+	// immediate cmd.Process.Kill() (SIGKILL), which would race the grace
+	// period below -- SIGKILLing the process the instant Cancel calls
+	// r.cancel(), before gracePeriod has any chance to elapse. Overriding
+	// Cancel to send SIGTERM to the whole process group instead, and
+	// WaitDelay to bound how long os/exec waits before it force-kills,
+	// restores the intended escalation.
+	cmd.Cancel = func() error {
+		killGroup(cmd, syscall.SIGTERM)
+		time.AfterFunc(gracePeriod, func() { killGroup(cmd, syscall.SIGKILL) })
+		return nil
+	}
+	cmd.WaitDelay = gracePeriod
+
+	return cmd
+}
+
+// Cancel flushes any buffered output, then cancels the run's context. The
+// actual SIGTERM -> gracePeriod -> SIGKILL escalation lives in the
+// cmd.Cancel/cmd.WaitDelay wiring set up in Command, so cancelling here is
+// what starts that escalation rather than short-circuiting straight to
+// SIGKILL.
+func (r *Run) Cancel() {
+	r.mu.Lock()
+	flush := r.flush
+	r.mu.Unlock()
+
+	if flush != nil {
+		flush()
+	}
+
+	r.cancel()
+}
+
+// Finish disarms the overall deadline, drops r from the registry, and -- if
+// failure is non-nil -- notifies the app's configured issue tracker. The
+// runner calls this once the task reaches a terminal state, passing the
+// failure details for a failed task and nil otherwise.
+func (r *Run) Finish(failure *trackers.TaskFailure) {
+	r.overall.Stop()
+	r.cancel()
+
+	registryMu.Lock()
+	delete(registry, r.TaskID)
+	registryMu.Unlock()
+
+	if failure != nil {
+		trackers.NotifyTaskFailure(r.AppID, *failure)
+	}
+}
+
+// CancelApp cancels every currently registered run for appID -- the handler
+// behind POST /api/apps/{app_id}/cancel.
+func CancelApp(appID string) {
+	registryMu.Lock()
+	var runs []*Run
+	for _, run := range registry {
+		if run.AppID == appID {
+			runs = append(runs, run)
+		}
+	}
+	registryMu.Unlock()
+
+	for _, run := range runs {
+		run.Cancel()
+	}
+}
+
+// CancelTask cancels a single run by task ID -- the handler behind
+// POST /api/project/{id}/tasks/{tid}/cancel. It reports whether a matching
+// run was found.
+func CancelTask(taskID int) bool {
+	registryMu.Lock()
+	run, ok := registry[taskID]
+	registryMu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	run.Cancel()
+	return true
+}
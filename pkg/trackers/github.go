@@ -0,0 +1,66 @@
+package trackers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ansible-semaphore/semaphore/db"
+)
+
+type githubTracker struct {
+	cfg db.IntegrationTracker
+}
+
+func newGithubTracker(cfg db.IntegrationTracker) *githubTracker {
+	return &githubTracker{cfg: cfg}
+}
+
+func (t *githubTracker) CreateIssue(ctx context.Context, failure TaskFailure) (string, string, error) {
+	body := map[string]interface{}{
+		"title": fmt.Sprintf("Task failure: %s", failure.TemplateName),
+		"body":  buildDescription(failure),
+	}
+
+	if labels, ok := renderFields(t.cfg.Fields, failure)["labels"]; ok {
+		body["labels"] = strings.Split(labels, ",")
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/issues", t.cfg.Endpoint, t.cfg.ProjectKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "token "+t.cfg.CredentialRef)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", "", fmt.Errorf("trackers: github returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var created struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", "", err
+	}
+
+	return fmt.Sprintf("%d", created.Number), created.HTMLURL, nil
+}
@@ -0,0 +1,66 @@
+package trackers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ansible-semaphore/semaphore/db"
+)
+
+type gitlabTracker struct {
+	cfg db.IntegrationTracker
+}
+
+func newGitlabTracker(cfg db.IntegrationTracker) *gitlabTracker {
+	return &gitlabTracker{cfg: cfg}
+}
+
+func (t *gitlabTracker) CreateIssue(ctx context.Context, failure TaskFailure) (string, string, error) {
+	body := map[string]interface{}{
+		"title":       fmt.Sprintf("Task failure: %s", failure.TemplateName),
+		"description": buildDescription(failure),
+	}
+
+	if labels, ok := renderFields(t.cfg.Fields, failure)["labels"]; ok {
+		body["labels"] = labels
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/issues", t.cfg.Endpoint, url.PathEscape(t.cfg.ProjectKey))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", t.cfg.CredentialRef)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", "", fmt.Errorf("trackers: gitlab returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var created struct {
+		IID    int    `json:"iid"`
+		WebURL string `json:"web_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", "", err
+	}
+
+	return fmt.Sprintf("%d", created.IID), created.WebURL, nil
+}
@@ -0,0 +1,73 @@
+package trackers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ansible-semaphore/semaphore/db"
+)
+
+type jiraTracker struct {
+	cfg db.IntegrationTracker
+}
+
+func newJiraTracker(cfg db.IntegrationTracker) *jiraTracker {
+	return &jiraTracker{cfg: cfg}
+}
+
+func (t *jiraTracker) CreateIssue(ctx context.Context, failure TaskFailure) (string, string, error) {
+	fields := map[string]interface{}{
+		"project":     map[string]string{"key": t.cfg.ProjectKey},
+		"summary":     fmt.Sprintf("Task failure: %s", failure.TemplateName),
+		"description": buildDescription(failure),
+		"issuetype":   map[string]string{"name": t.cfg.IssueType},
+	}
+
+	for field, value := range renderFields(t.cfg.Fields, failure) {
+		fields[field] = value
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"fields": fields})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.cfg.Endpoint+"/rest/api/2/issue", bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+t.cfg.CredentialRef)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", "", fmt.Errorf("trackers: jira returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var created struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", "", err
+	}
+
+	return created.Key, t.cfg.Endpoint + "/browse/" + created.Key, nil
+}
+
+func buildDescription(failure TaskFailure) string {
+	return fmt.Sprintf(
+		"Template: %s\nInventory: %s\nCommit: %s (%s)\n\nOutput:\n%s",
+		failure.TemplateName, failure.Inventory, failure.CommitHash, failure.CommitMessage, failure.Output,
+	)
+}
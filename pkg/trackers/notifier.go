@@ -0,0 +1,63 @@
+package trackers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ansible-semaphore/semaphore/util"
+)
+
+// maxAttempts and the backoff schedule below are deliberately small and
+// fixed; tracker APIs are flaky on a scale of seconds, not minutes, and a
+// failed notification should never block the task runner itself.
+const maxAttempts = 4
+
+var backoffSchedule = [maxAttempts - 1]time.Duration{
+	2 * time.Second,
+	8 * time.Second,
+	30 * time.Second,
+}
+
+// NotifyTaskFailure opens an issue for failure in the tracker configured for
+// appID, if any. The task runner calls this from Run.Finish (see pkg/tasks)
+// right after a task transitions to the failed state; the call returns
+// immediately and the actual HTTP round trip (plus retries) happens on a
+// background goroutine so a slow or unreachable tracker never delays the
+// runner.
+func NotifyTaskFailure(appID string, failure TaskFailure) {
+	cfg, ok := util.Config.Integrations()[appID]
+	if !ok || !cfg.Active {
+		return
+	}
+
+	tracker, err := New(cfg)
+	if err != nil {
+		log.Printf("trackers: %v", err)
+		return
+	}
+
+	go notifyWithRetry(tracker, appID, failure)
+}
+
+func notifyWithRetry(tracker Tracker, appID string, failure TaskFailure) {
+	var lastErr error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		id, url, err := tracker.CreateIssue(ctx, failure)
+		cancel()
+
+		if err == nil {
+			log.Printf("trackers: filed issue %s (%s) for app %s", id, url, appID)
+			return
+		}
+
+		lastErr = err
+		if attempt < len(backoffSchedule) {
+			time.Sleep(backoffSchedule[attempt])
+		}
+	}
+
+	log.Printf("trackers: giving up notifying app %s after %d attempts: %v", appID, maxAttempts, lastErr)
+}
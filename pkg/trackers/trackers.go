@@ -0,0 +1,63 @@
+// Package trackers implements outbound connectors that file an issue in an
+// external tracker (Jira, GitHub, GitLab) when a Semaphore task fails.
+package trackers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ansible-semaphore/semaphore/db"
+)
+
+// TaskFailure carries everything a Tracker needs to describe the failure in
+// the created issue.
+type TaskFailure struct {
+	TemplateName  string
+	Output        string
+	Inventory     string
+	CommitHash    string
+	CommitMessage string
+}
+
+// Tracker opens an issue for a failed task and returns its ID and a link the
+// user can follow.
+type Tracker interface {
+	CreateIssue(ctx context.Context, failure TaskFailure) (id string, url string, err error)
+}
+
+// New builds the Tracker implementation matching cfg.Kind.
+func New(cfg db.IntegrationTracker) (Tracker, error) {
+	switch cfg.Kind {
+	case db.IntegrationTrackerJira:
+		return newJiraTracker(cfg), nil
+	case db.IntegrationTrackerGithub:
+		return newGithubTracker(cfg), nil
+	case db.IntegrationTrackerGitlab:
+		return newGitlabTracker(cfg), nil
+	default:
+		return nil, fmt.Errorf("trackers: unknown tracker kind %q", cfg.Kind)
+	}
+}
+
+// renderFields resolves the user-defined custom field templates against a
+// failure, leaving literal values untouched when they contain no template
+// placeholders.
+func renderFields(fields map[string]string, failure TaskFailure) map[string]string {
+	out := make(map[string]string, len(fields))
+	for k, v := range fields {
+		out[k] = expand(v, failure)
+	}
+	return out
+}
+
+func expand(template string, failure TaskFailure) string {
+	replacer := strings.NewReplacer(
+		"{{.TemplateName}}", failure.TemplateName,
+		"{{.Output}}", failure.Output,
+		"{{.Inventory}}", failure.Inventory,
+		"{{.CommitHash}}", failure.CommitHash,
+		"{{.CommitMessage}}", failure.CommitMessage,
+	)
+	return replacer.Replace(template)
+}
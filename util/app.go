@@ -0,0 +1,60 @@
+package util
+
+import "time"
+
+// App describes the runner configuration for one template type (Ansible,
+// Terraform, Bash, ...). It is kept in Config.Apps, guarded by ConfigStore
+// so handlers and task runners never race on it, and persisted as part of
+// the config.json snapshot.
+//
+// The jsonschema tag (a comma-separated list of key=value pairs) is read by
+// pkg/appschema to generate the Draft-2020-12 schema served from
+// GET /api/apps/{app_id}/schema, which both validates writes in setApp and
+// lets the UI render a form for this struct without hand-written fields.
+type App struct {
+	Title     string `json:"title" jsonschema:"title=Title,description=Name shown in the template editor,default=,maxLength=100"`
+	Icon      string `json:"icon" jsonschema:"title=Icon,description=Identifier of the icon shown next to the app"`
+	Color     string `json:"color" jsonschema:"title=Color,description=Accent color,format=color,default=#000000"`
+	DarkColor string `json:"dark_color" jsonschema:"title=Dark mode color,format=color,default=#ffffff"`
+	Active    bool   `json:"active" jsonschema:"title=Active,description=Whether this app is selectable when creating a template,default=true"`
+
+	// Timeouts bounds how long a task run under this app is allowed to take
+	// before Semaphore cancels it itself, independent of any timeout the
+	// underlying tool (ansible-playbook, terraform, ...) enforces.
+	Timeouts AppTimeouts `json:"timeouts"`
+
+	// AnsibleAppOptions and BashAppOptions are embedded rather than nested
+	// under their own JSON key, so e.g. "shell_type" sits next to "title" at
+	// the top level of an app's JSON the same way encoding/json would
+	// promote it -- pkg/options.Flatten, db.AssignMapToStruct and
+	// pkg/appschema.Generate all special-case anonymous fields to match.
+	// Every template type shares one App schema today (see
+	// pkg/appschema/registry.go), so a bash app simply never sets
+	// playbook_flags and vice versa; there's no per-type App variant to
+	// embed these into separately.
+	AnsibleAppOptions
+	BashAppOptions
+}
+
+// AppTimeouts are an app's deadlines around task execution; pkg/tasks.Run
+// enforces Step and Overall. Zero means "no Semaphore-side limit".
+type AppTimeouts struct {
+	// Connect is the timeout for the initial connection phase (e.g. the SSH
+	// handshake ansible-playbook performs before running anything). It is
+	// stored and editable, but pkg/tasks.Run does not enforce it.
+	Connect time.Duration `json:"connect" jsonschema:"title=Connect timeout (ns)"`
+	// Step bounds a single step of the task.
+	Step time.Duration `json:"step" jsonschema:"title=Per-step timeout (ns)"`
+	// Overall bounds the task run as a whole.
+	Overall time.Duration `json:"overall" jsonschema:"title=Overall timeout (ns)"`
+}
+
+// BashAppOptions are the Bash-specific fields embedded in App.
+type BashAppOptions struct {
+	ShellType string `json:"shell_type" jsonschema:"title=Shell,enum=bash|sh|zsh,default=bash"`
+}
+
+// AnsibleAppOptions are the Ansible-specific fields embedded in App.
+type AnsibleAppOptions struct {
+	PlaybookFlags string `json:"playbook_flags" jsonschema:"title=ansible-playbook flags,description=Extra flags appended to every ansible-playbook invocation"`
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"os"
+
+	"github.com/ansible-semaphore/semaphore/db"
+)
+
+// ConfigType holds the subset of Semaphore's runtime configuration that is
+// editable through the API and persisted by ConfigStore to the config.json
+// file at configFilePath.
+type ConfigType struct {
+	Apps map[string]App `json:"apps"`
+
+	// Integrations maps an app_id to the issue-tracker connector Semaphore
+	// should notify when a task for that app fails.
+	Integrations map[string]db.IntegrationTracker `json:"integrations"`
+}
+
+// Config is the process-wide, concurrency-safe view of ConfigType. Handlers
+// read it via Config.Apps()/Config.Integrations() and write through
+// Config.WithApps()/Config.WithIntegrations(), which also keep
+// configFilePath on disk in sync. It is nil until Init runs.
+var Config *ConfigStore
+
+// Init constructs Config, loading configFilePath() if it exists and starting
+// the fsnotify watch that keeps it in sync with out-of-process edits, then
+// migrates any options still written under the pre-ConfigStore "apps.<id>.*"
+// flat-key scheme (see MigrateFlatAppOptions) into it. Call this once during
+// startup, after the options store is available and before any handler reads
+// or writes Config.
+//
+// Config used to be built by a package-var initializer, which meant every
+// importer of this package touched disk and spun up a watcher goroutine as a
+// side effect of import, before main() (or a test) had a chance to set
+// SEMAPHORE_CONFIG_PATH or otherwise control the environment.
+func Init(store db.Store, legacyOptions map[string]string) error {
+	Config = NewConfigStore(configFilePath())
+	return MigrateFlatAppOptions(store, legacyOptions)
+}
+
+func configFilePath() string {
+	if path := os.Getenv("SEMAPHORE_CONFIG_PATH"); path != "" {
+		return path
+	}
+	return "config.json"
+}
@@ -0,0 +1,289 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ansible-semaphore/semaphore/db"
+	"github.com/fsnotify/fsnotify"
+)
+
+// ConfigStore guards ConfigType behind an RWMutex and keeps it in sync with
+// a JSON file on disk, so the HTTP handlers that edit apps/integrations and
+// the task runners/schedulers that read them concurrently never observe a
+// partially-written map. Every successful write bumps Revision and wakes up
+// any goroutine blocked in WaitForChange (the long-poll GET /api/apps
+// handler uses this to push remote edits to the UI without a fixed poll
+// interval).
+type ConfigStore struct {
+	mu   sync.RWMutex
+	data ConfigType
+	path string
+
+	// notifyMu guards revision and subs together, so "read revision, decide
+	// whether to subscribe" (WaitForChange) and "bump revision, wake
+	// subscribers" (bumpRevision) never interleave -- see WaitForChange.
+	notifyMu sync.Mutex
+	revision uint64
+	subs     map[chan struct{}]struct{}
+}
+
+// NewConfigStore loads path if it exists (a missing file just means an
+// empty store -- this is the common case on first run) and starts an
+// fsnotify watch so edits made outside this process (another replica, a
+// human editing config.json directly) are picked up without a restart.
+func NewConfigStore(path string) *ConfigStore {
+	s := &ConfigStore{
+		path: path,
+		data: ConfigType{
+			Apps:         map[string]App{},
+			Integrations: map[string]db.IntegrationTracker{},
+		},
+		subs: map[chan struct{}]struct{}{},
+	}
+
+	if err := s.reload(); err != nil && !os.IsNotExist(err) {
+		log.Printf("util: failed to load %s: %v", path, err)
+	}
+
+	if err := s.watch(); err != nil {
+		log.Printf("util: fsnotify watch on %s disabled: %v", path, err)
+	}
+
+	return s
+}
+
+func (s *ConfigStore) reload() error {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	var data ConfigType
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	if data.Apps == nil {
+		data.Apps = map[string]App{}
+	}
+	if data.Integrations == nil {
+		data.Integrations = map[string]db.IntegrationTracker{}
+	}
+
+	s.mu.Lock()
+	s.data = data
+	s.mu.Unlock()
+
+	s.bumpRevision()
+
+	return nil
+}
+
+func (s *ConfigStore) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go func() {
+		for event := range watcher.Events {
+			if filepath.Clean(event.Name) != filepath.Clean(s.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := s.reload(); err != nil {
+				log.Printf("util: reload %s: %v", s.path, err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Apps returns a snapshot of the current apps map. It is safe to read
+// concurrently with any WithApps call; mutating the returned map has no
+// effect on the store.
+func (s *ConfigStore) Apps() map[string]App {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneApps(s.data.Apps)
+}
+
+// Integrations mirrors Apps for the issue-tracker config.
+func (s *ConfigStore) Integrations() map[string]db.IntegrationTracker {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneIntegrations(s.data.Integrations)
+}
+
+// Revision returns the store's current revision counter.
+func (s *ConfigStore) Revision() uint64 {
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	return s.revision
+}
+
+// WithApps runs fn against a private copy of the current apps map. If fn
+// returns an error, or persisting the result to disk fails, the store is
+// left exactly as it was -- callers don't need to worry about partial
+// writes leaking into memory or onto disk.
+func (s *ConfigStore) WithApps(fn func(apps map[string]App) error) error {
+	s.mu.Lock()
+
+	apps := cloneApps(s.data.Apps)
+	if err := fn(apps); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	next := s.data
+	next.Apps = apps
+
+	if err := s.persist(next); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	s.data = next
+	s.mu.Unlock()
+
+	s.bumpRevision()
+
+	return nil
+}
+
+// WithIntegrations mirrors WithApps for the integrations map.
+func (s *ConfigStore) WithIntegrations(fn func(integrations map[string]db.IntegrationTracker) error) error {
+	s.mu.Lock()
+
+	integrations := cloneIntegrations(s.data.Integrations)
+	if err := fn(integrations); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	next := s.data
+	next.Integrations = integrations
+
+	if err := s.persist(next); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	s.data = next
+	s.mu.Unlock()
+
+	s.bumpRevision()
+
+	return nil
+}
+
+// persist writes data to a temp file and renames it over s.path, so a crash
+// mid-write never leaves a half-written config.json behind. Mode 0o600
+// because data includes IntegrationTracker.CredentialRef in cleartext --
+// this file is not an appropriate long-term home for a real secret store,
+// but until one exists, keep it at least unreadable by anyone but the
+// process owner.
+func (s *ConfigStore) persist(data ConfigType) error {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+// WaitForChange blocks until the revision differs from after or ctx is
+// done, returning the revision observed at that point; if it already differs
+// (higher or lower), it returns at once. Passing the store's
+// current revision lets a caller long-poll for the next change.
+//
+// The revision check and the subscription it registers on a miss both run
+// under notifyMu, the same lock bumpRevision takes to bump the revision and
+// wake subscribers -- otherwise a bumpRevision between this check and the
+// subscribe could close out every then-registered subscriber before this one
+// joins, and this call would miss the change it was supposed to observe.
+func (s *ConfigStore) WaitForChange(ctx context.Context, after uint64) uint64 {
+	s.notifyMu.Lock()
+	if s.revision != after {
+		rev := s.revision
+		s.notifyMu.Unlock()
+		return rev
+	}
+
+	ch := make(chan struct{})
+	s.subs[ch] = struct{}{}
+	s.notifyMu.Unlock()
+
+	select {
+	case <-ch:
+	case <-ctx.Done():
+		// bumpRevision didn't wake us, so ch is still registered -- drop it,
+		// or every long-poll client that disconnects before the next edit
+		// leaks one entry in s.subs forever.
+		s.notifyMu.Lock()
+		delete(s.subs, ch)
+		s.notifyMu.Unlock()
+	}
+
+	return s.Revision()
+}
+
+// bumpRevision advances the revision counter and wakes every goroutine
+// currently blocked in WaitForChange.
+func (s *ConfigStore) bumpRevision() {
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+
+	s.revision++
+
+	for ch := range s.subs {
+		close(ch)
+	}
+	s.subs = map[chan struct{}]struct{}{}
+}
+
+func cloneApps(in map[string]App) map[string]App {
+	out := make(map[string]App, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// cloneIntegrations deep-copies each record's Fields map too -- IntegrationTracker
+// itself copies by value, but Fields is a reference type, so a shallow copy
+// would let a caller mutating the "snapshot" Fields map reach back into the
+// store's own data.
+func cloneIntegrations(in map[string]db.IntegrationTracker) map[string]db.IntegrationTracker {
+	out := make(map[string]db.IntegrationTracker, len(in))
+	for k, v := range in {
+		if v.Fields != nil {
+			fields := make(map[string]string, len(v.Fields))
+			for fk, fv := range v.Fields {
+				fields[fk] = fv
+			}
+			v.Fields = fields
+		}
+		out[k] = v
+	}
+	return out
+}
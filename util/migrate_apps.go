@@ -0,0 +1,61 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/ansible-semaphore/semaphore/db"
+)
+
+// MigrateFlatAppOptions converts the legacy "apps.<id>.<field>" options
+// (written one key at a time by the pre-ConfigStore setAppOption) into the
+// versioned, file-backed Config.Apps record, then deletes the legacy keys
+// from the options store. legacyOptions is everything the options table
+// holds; only the "apps." prefix is touched. Called once from Init.
+func MigrateFlatAppOptions(store db.Store, legacyOptions map[string]string) error {
+	const prefix = "apps."
+
+	flat := map[string]string{}
+	for key, value := range legacyOptions {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		flat[strings.TrimPrefix(key, prefix)] = value
+	}
+
+	if len(flat) == 0 {
+		return nil
+	}
+
+	nested := db.ConvertFlatToNested(flat)
+
+	migrated := map[string]App{}
+	for appID, raw := range nested {
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var app App
+		if err := db.AssignMapToStruct(fields, &app); err != nil {
+			return err
+		}
+		migrated[appID] = app
+	}
+
+	if err := Config.WithApps(func(apps map[string]App) error {
+		for appID, app := range migrated {
+			apps[appID] = app
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for key := range legacyOptions {
+		if strings.HasPrefix(key, prefix) {
+			_ = store.DeleteOptions(key)
+		}
+	}
+
+	return nil
+}